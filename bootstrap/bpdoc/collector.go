@@ -0,0 +1,139 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import "fmt"
+
+// DocCollector accumulates the PropertyStructs discovered while walking one
+// or more packages so that, once all of them are known, references between
+// them can be resolved: a property whose type is a named struct (rather
+// than an anonymous one that's already inlined by structProperties) can be
+// linked to the PropertyStruct that documents it.
+type DocCollector struct {
+	structs map[string]*PropertyStruct
+}
+
+// NewDocCollector returns an empty DocCollector.
+func NewDocCollector() *DocCollector {
+	return &DocCollector{structs: make(map[string]*PropertyStruct)}
+}
+
+// Add registers ps as having been found in the package at pkgPath. It must
+// be called for every PropertyStruct before ResolveReferences is run.
+func (c *DocCollector) Add(pkgPath string, ps *PropertyStruct) {
+	ps.collector = c
+	c.structs[refKey(pkgPath, ps.Name)] = ps
+}
+
+// ResolveReferences walks every PropertyStruct registered with c and, for
+// each property whose type is a named struct with no inlined properties of
+// its own, records the package and name of the PropertyStruct that
+// documents it in Property.RefPkg and Property.RefName.
+func (c *DocCollector) ResolveReferences() {
+	for key, ps := range c.structs {
+		pkgPath := pkgPathFromKey(key)
+		resolveRefs(ps.Properties, pkgPath, c)
+	}
+}
+
+func resolveRefs(props []Property, fromPkg string, c *DocCollector) {
+	for i := range props {
+		p := &props[i]
+		if len(p.Properties) == 0 && isNamedStructType(p.Type) {
+			if target, pkg, ok := c.find(fromPkg, p.Type); ok {
+				p.RefPkg = pkg
+				p.RefName = target.Name
+			}
+		}
+		resolveRefs(p.Properties, fromPkg, c)
+	}
+}
+
+// find looks up a PropertyStruct named name, preferring one declared in
+// fromPkg (the package of the property referencing it) to disambiguate
+// between identically-named structs in different packages. If name is
+// ambiguous across other packages too, the one from the
+// lexicographically smallest package path is chosen, so the result is
+// deterministic across runs rather than depending on map iteration order.
+func (c *DocCollector) find(fromPkg, name string) (*PropertyStruct, string, bool) {
+	if ps, ok := c.structs[refKey(fromPkg, name)]; ok {
+		return ps, fromPkg, true
+	}
+	var bestPkg string
+	var best *PropertyStruct
+	for key, ps := range c.structs {
+		if ps.Name != name {
+			continue
+		}
+		pkg := pkgPathFromKey(key)
+		if best == nil || pkg < bestPkg {
+			best, bestPkg = ps, pkg
+		}
+	}
+	return best, bestPkg, best != nil
+}
+
+// lookup returns the PropertyStruct registered for pkgPath and name.
+func (c *DocCollector) lookup(pkgPath, name string) (*PropertyStruct, bool) {
+	ps, ok := c.structs[refKey(pkgPath, name)]
+	return ps, ok
+}
+
+func refKey(pkgPath, name string) string {
+	return fmt.Sprintf("%s.%s", pkgPath, name)
+}
+
+func pkgPathFromKey(key string) string {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' {
+			return key[:i]
+		}
+	}
+	return ""
+}
+
+// Inline returns a copy of ps with up to depth levels of cross-referenced
+// properties expanded to show the referenced PropertyStruct's own
+// properties, instead of just a bare type name. A depth of 0 returns an
+// unmodified copy. Cycles (a struct that transitively references itself)
+// are detected and left unexpanded rather than recursing forever.
+func (ps *PropertyStruct) Inline(depth int) *PropertyStruct {
+	ret := ps.Clone()
+	if depth <= 0 || ps.collector == nil {
+		return ret
+	}
+	visiting := map[*PropertyStruct]bool{ps: true}
+	inlineProps(ret.Properties, depth, ps.collector, visiting)
+	return ret
+}
+
+func inlineProps(props []Property, depth int, c *DocCollector, visiting map[*PropertyStruct]bool) {
+	for i := range props {
+		p := &props[i]
+		if p.RefName == "" || len(p.Properties) != 0 {
+			inlineProps(p.Properties, depth, c, visiting)
+			continue
+		}
+		target, ok := c.lookup(p.RefPkg, p.RefName)
+		if !ok || visiting[target] || depth <= 0 {
+			continue
+		}
+		visiting[target] = true
+		expanded := target.Clone()
+		inlineProps(expanded.Properties, depth-1, c, visiting)
+		p.Properties = expanded.Properties
+		delete(visiting, target)
+	}
+}
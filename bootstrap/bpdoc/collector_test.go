@@ -0,0 +1,163 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveReferences(t *testing.T) {
+	c := NewDocCollector()
+
+	target := &PropertyStruct{
+		Name:       "Target",
+		Properties: []Property{{Name: "inner", Type: "string"}},
+	}
+	c.Add("pkg/a", target)
+
+	referrer := &PropertyStruct{
+		Name: "Referrer",
+		Properties: []Property{
+			{Name: "ref", Type: "Target"},
+		},
+	}
+	c.Add("pkg/b", referrer)
+
+	c.ResolveReferences()
+
+	ref := findProperty(referrer.Properties, "ref")
+	if ref == nil {
+		t.Fatalf("missing ref property")
+	}
+	if ref.RefPkg != "pkg/a" || ref.RefName != "Target" {
+		t.Errorf("ref = {RefPkg: %q, RefName: %q}, want {RefPkg: %q, RefName: %q}", ref.RefPkg, ref.RefName, "pkg/a", "Target")
+	}
+}
+
+// TestFindPrefersSamePackage verifies that find disambiguates an
+// ambiguous struct name by preferring the one declared in the same
+// package as the referencing property, even though another candidate's
+// package path sorts earlier lexicographically.
+func TestFindPrefersSamePackage(t *testing.T) {
+	c := NewDocCollector()
+	c.Add("pkg/a", &PropertyStruct{Name: "Dup"})
+	same := &PropertyStruct{Name: "Dup"}
+	c.Add("pkg/b", same)
+
+	got, pkg, ok := c.find("pkg/b", "Dup")
+	if !ok {
+		t.Fatalf("find did not resolve Dup")
+	}
+	if got != same || pkg != "pkg/b" {
+		t.Errorf("find resolved to package %q, want %q (same-package match)", pkg, "pkg/b")
+	}
+}
+
+// TestFindAmbiguousNameIsDeterministic verifies that when a name is
+// ambiguous across packages other than the referencing one, find always
+// picks the lexicographically smallest package path, rather than
+// depending on map iteration order.
+func TestFindAmbiguousNameIsDeterministic(t *testing.T) {
+	c := NewDocCollector()
+	c.Add("pkg/z", &PropertyStruct{Name: "Dup"})
+	want := &PropertyStruct{Name: "Dup"}
+	c.Add("pkg/a", want)
+	c.Add("pkg/m", &PropertyStruct{Name: "Dup"})
+
+	for i := 0; i < 10; i++ {
+		got, pkg, ok := c.find("pkg/other", "Dup")
+		if !ok {
+			t.Fatalf("find did not resolve Dup")
+		}
+		if got != want || pkg != "pkg/a" {
+			t.Fatalf("find resolved to package %q, want %q", pkg, "pkg/a")
+		}
+	}
+}
+
+func TestInlineExpandsReference(t *testing.T) {
+	c := NewDocCollector()
+	target := &PropertyStruct{
+		Name:       "Target",
+		Properties: []Property{{Name: "inner", Type: "string"}},
+	}
+	c.Add("pkg/a", target)
+
+	referrer := &PropertyStruct{
+		Name: "Referrer",
+		Properties: []Property{
+			{Name: "ref", Type: "Target"},
+		},
+	}
+	c.Add("pkg/b", referrer)
+	c.ResolveReferences()
+
+	inlined := referrer.Inline(1)
+	ref := findProperty(inlined.Properties, "ref")
+	if ref == nil {
+		t.Fatalf("missing ref property")
+	}
+	if findProperty(ref.Properties, "inner") == nil {
+		t.Errorf("Inline did not expand the referenced struct's properties")
+	}
+}
+
+// TestInlineDetectsCycles verifies that Inline doesn't recurse forever
+// when a struct transitively references itself, leaving the cyclic
+// reference unexpanded instead.
+func TestInlineDetectsCycles(t *testing.T) {
+	c := NewDocCollector()
+
+	a := &PropertyStruct{
+		Name: "A",
+		Properties: []Property{
+			{Name: "toB", Type: "B"},
+		},
+	}
+	b := &PropertyStruct{
+		Name: "B",
+		Properties: []Property{
+			{Name: "toA", Type: "A"},
+		},
+	}
+	c.Add("pkg", a)
+	c.Add("pkg", b)
+	c.ResolveReferences()
+
+	done := make(chan *PropertyStruct, 1)
+	go func() {
+		done <- a.Inline(10)
+	}()
+
+	var inlined *PropertyStruct
+	select {
+	case inlined = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Inline did not return, likely stuck in an infinite cycle")
+	}
+
+	toB := findProperty(inlined.Properties, "toB")
+	if toB == nil {
+		t.Fatalf("missing toB property")
+	}
+	toA := findProperty(toB.Properties, "toA")
+	if toA == nil {
+		t.Fatalf("missing toA property")
+	}
+	if len(toA.Properties) != 0 {
+		t.Errorf("Inline expanded the cyclic reference back to A instead of leaving it unexpanded")
+	}
+}
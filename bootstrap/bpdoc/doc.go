@@ -0,0 +1,93 @@
+// Copyright 2019 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bpdoc extracts property documentation from the Go source of
+// Blueprint module types so it can be rendered into reference
+// documentation.
+package bpdoc
+
+import (
+	"html/template"
+	"reflect"
+)
+
+// Package documents a single Go package that contains module types or
+// property structs used by Blueprint module types.
+type Package struct {
+	Name string
+	Path string
+	Text template.HTML
+
+	PropertyStructs []*PropertyStruct
+	ModuleTypes     []*ModuleType
+}
+
+// ModuleType documents a single module type registered with Blueprint,
+// along with the property structs that describe its properties.
+type ModuleType struct {
+	Name    string
+	PkgPath string
+	Text    template.HTML
+
+	PropertyStructs []*PropertyStruct
+}
+
+// PropertyStruct documents a single Go struct used to hold the
+// properties of a module type or one of its nested property structs.
+//
+// Text holds the type's doc comment as plain text (not HTML-escaped,
+// unlike Property.Text), since it comes straight from go/doc rather
+// than through formatText.
+type PropertyStruct struct {
+	Name       string
+	Text       string
+	Properties []Property
+
+	// collector is the DocCollector this PropertyStruct was registered
+	// with, if any. It's used by Inline to look up the PropertyStructs
+	// that cross-referenced properties point to.
+	collector *DocCollector
+}
+
+// Property documents a single field of a PropertyStruct.
+type Property struct {
+	Name       string
+	OtherNames []string
+	Type       string
+	Tag        reflect.StructTag
+	Text       template.HTML
+	OtherTexts []template.HTML
+	Properties []Property
+	Anonymous  bool
+
+	Default string
+
+	// Deprecated is the deprecation message from a `blueprint:"deprecated=..."`
+	// struct tag, or the empty string if the property isn't deprecated.
+	Deprecated string
+	// Since is the value of a `blueprint:"since=..."` struct tag, typically
+	// the version the property was introduced in, or the empty string if
+	// not set.
+	Since string
+	// Stability is the value of a `blueprint:"stability=..."` struct tag,
+	// for example "experimental", or the empty string if not set.
+	Stability string
+
+	// RefPkg and RefName identify the PropertyStruct that this property's
+	// named struct type refers to, as resolved by
+	// DocCollector.ResolveReferences. They're empty unless the property's
+	// type is a named struct and the PropertyStruct it refers to was found.
+	RefPkg  string
+	RefName string
+}
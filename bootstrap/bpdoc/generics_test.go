@@ -0,0 +1,160 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const genericPropsSrc = `
+package test
+
+type GenericProps struct {
+	// Config is a single-parameter generic.
+	Config proptools.Configurable[string]
+
+	// Pair is a multi-parameter generic whose arguments are anonymous
+	// structs.
+	Pair Union[struct {
+		// A is a field of the first struct.
+		A string
+	}, struct {
+		// B is a field of the second struct.
+		B string
+	}]
+}
+`
+
+func genericPropsStructType(t *testing.T) *ast.StructType {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "generics_test_fixture.go", genericPropsSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse fixture source: %s", err)
+	}
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != "GenericProps" {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				t.Fatalf("GenericProps is not a struct type")
+			}
+			return st
+		}
+	}
+
+	t.Fatalf("GenericProps not found in fixture source")
+	return nil
+}
+
+func TestGetTypeSingleParamGeneric(t *testing.T) {
+	props, err := structProperties(genericPropsStructType(t))
+	if err != nil {
+		t.Fatalf("structProperties returned error: %s", err)
+	}
+
+	config := findProperty(props, "config")
+	if config == nil {
+		t.Fatalf("missing Config property")
+	}
+	if config.Type != "configurable string" {
+		t.Errorf("Config.Type = %q, want %q", config.Type, "configurable string")
+	}
+}
+
+// TestGetTypeMultiParamGenericWithStructArgs covers the case of a
+// multi-parameter generic (via *ast.IndexListExpr) whose type arguments
+// are themselves anonymous structs: each argument must stringify as
+// "struct" rather than "", and every argument's inner properties must be
+// preserved rather than only the first's.
+func TestGetTypeMultiParamGenericWithStructArgs(t *testing.T) {
+	props, err := structProperties(genericPropsStructType(t))
+	if err != nil {
+		t.Fatalf("structProperties returned error: %s", err)
+	}
+
+	pair := findProperty(props, "pair")
+	if pair == nil {
+		t.Fatalf("missing Pair property")
+	}
+	if pair.Type != "union<struct,struct>" {
+		t.Errorf("Pair.Type = %q, want %q", pair.Type, "union<struct,struct>")
+	}
+	if findProperty(pair.Properties, "a") == nil {
+		t.Errorf("Pair is missing inner property A from its first struct argument")
+	}
+	if findProperty(pair.Properties, "b") == nil {
+		t.Errorf("Pair is missing inner property B from its second struct argument")
+	}
+}
+
+func TestGenericBaseName(t *testing.T) {
+	tests := []struct {
+		name string
+		expr ast.Expr
+		want string
+	}{
+		{
+			name: "bare identifier",
+			expr: &ast.Ident{Name: "Optional"},
+			want: "Optional",
+		},
+		{
+			name: "package-qualified selector",
+			expr: &ast.SelectorExpr{X: &ast.Ident{Name: "proptools"}, Sel: &ast.Ident{Name: "Configurable"}},
+			want: "Configurable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := genericBaseName(tt.expr)
+			if err != nil {
+				t.Fatalf("genericBaseName returned error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("genericBaseName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterGenericWrapper(t *testing.T) {
+	defer delete(genericWrappers, "TestWrapper")
+
+	RegisterGenericWrapper("TestWrapper", func(inner ...string) string {
+		return "wrapped(" + inner[0] + ")"
+	})
+
+	typ, _, err := getGenericType(&ast.Ident{Name: "TestWrapper"}, []ast.Expr{&ast.Ident{Name: "int"}})
+	if err != nil {
+		t.Fatalf("getGenericType returned error: %s", err)
+	}
+	if typ != "wrapped(int)" {
+		t.Errorf("getGenericType() = %q, want %q", typ, "wrapped(int)")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func renderJSONSchema(ps *PropertyStruct) (string, error) {
+	defs := map[string]interface{}{}
+	schema := map[string]interface{}{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"title":      ps.Name,
+		"type":       "object",
+		"properties": propertiesToJSONSchema(ps.Properties, defs, ps.collector),
+	}
+	if ps.Text != "" {
+		schema["description"] = strings.TrimSpace(ps.Text)
+	}
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// propertiesToJSONSchema does not apply any tag-based filtering of its
+// own; callers that want to exclude properties from the rendered schema
+// should call PropertyStruct.ExcludeByTag/IncludeByTag before rendering,
+// which filters consistently across RenderMarkdown, RenderYAML, and
+// RenderJSONSchema alike.
+func propertiesToJSONSchema(props []Property, defs map[string]interface{}, c *DocCollector) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, p := range props {
+		out[p.Name] = propertyToJSONSchema(p, defs, c)
+	}
+	return out
+}
+
+// propertyToJSONSchema maps a single bpdoc Property to a JSON Schema
+// fragment. It understands the handful of synthetic type strings that
+// getType produces: "list of X", "configurable X", "optional X",
+// "map of X to Y", "struct", and "interface", in addition to the Go
+// primitive type names. A property whose type was resolved by
+// DocCollector.ResolveReferences is emitted as
+// a "$ref" into "$defs" instead.
+func propertyToJSONSchema(p Property, defs map[string]interface{}, c *DocCollector) map[string]interface{} {
+	var schema map[string]interface{}
+	if p.RefName != "" && len(p.Properties) == 0 {
+		schema = refJSONSchema(p.RefPkg, p.RefName, defs, c)
+	} else {
+		schema = typeStringToJSONSchema(p.Type, p.Properties, defs, c)
+	}
+	if p.Text != "" {
+		schema["description"] = htmlToText(p.Text)
+	}
+	if p.Default != "" {
+		schema["default"] = p.Default
+	}
+	if p.Deprecated != "" {
+		schema["deprecated"] = true
+		schema["deprecationMessage"] = p.Deprecated
+	}
+	if p.Since != "" {
+		schema["since"] = p.Since
+	}
+	if p.Stability != "" {
+		schema["stability"] = p.Stability
+	}
+	return schema
+}
+
+func typeStringToJSONSchema(typ string, innerProps []Property, defs map[string]interface{}, c *DocCollector) map[string]interface{} {
+	switch {
+	case strings.HasPrefix(typ, "list of "):
+		elt := strings.TrimPrefix(typ, "list of ")
+		return map[string]interface{}{
+			"type":  "array",
+			"items": typeStringToJSONSchema(elt, innerProps, defs, c),
+		}
+	case strings.HasPrefix(typ, "configurable "):
+		// A configurable property accepts either a literal value or a
+		// conditional expression; from a schema consumer's point of view
+		// it's indistinguishable from the underlying type.
+		return typeStringToJSONSchema(strings.TrimPrefix(typ, "configurable "), innerProps, defs, c)
+	case strings.HasPrefix(typ, "optional "):
+		// An optional property that's unset is simply absent; the schema
+		// for the value it holds when present is the same as the
+		// underlying type's.
+		return typeStringToJSONSchema(strings.TrimPrefix(typ, "optional "), innerProps, defs, c)
+	case strings.HasPrefix(typ, "map of "):
+		rest := strings.TrimPrefix(typ, "map of ")
+		schema := map[string]interface{}{"type": "object"}
+		if idx := strings.Index(rest, " to "); idx >= 0 {
+			schema["additionalProperties"] = typeStringToJSONSchema(rest[idx+len(" to "):], innerProps, defs, c)
+		}
+		return schema
+	case typ == "interface" || typ == "":
+		return map[string]interface{}{}
+	case len(innerProps) > 0:
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": propertiesToJSONSchema(innerProps, defs, c),
+		}
+	case isNamedStructType(typ):
+		// No cross-reference was resolved for this property (e.g. the
+		// PropertyStruct was rendered without a DocCollector), so the
+		// referenced struct's properties aren't known.
+		return refJSONSchema("", typ, defs, c)
+	default:
+		return map[string]interface{}{"type": goTypeToJSONSchemaType(typ)}
+	}
+}
+
+// refJSONSchema returns a "$ref" pointing into "$defs", populating the
+// def the first time pkg+name is seen. Defs are keyed by pkg+"."+name
+// rather than the bare struct name so that two PropertyStructs that
+// share a name in different packages don't collide. If c can resolve
+// pkg+name to a PropertyStruct, the def is filled in with that struct's
+// own properties; otherwise it's left as a bare, property-less object.
+func refJSONSchema(pkg, name string, defs map[string]interface{}, c *DocCollector) map[string]interface{} {
+	key := name
+	if pkg != "" {
+		key = refKey(pkg, name)
+	}
+	if _, ok := defs[key]; !ok {
+		def := map[string]interface{}{"type": "object"}
+		defs[key] = def
+		if c != nil {
+			if target, ok := c.lookup(pkg, name); ok {
+				def["properties"] = propertiesToJSONSchema(target.Properties, defs, c)
+			}
+		}
+	}
+	return map[string]interface{}{"$ref": "#/$defs/" + jsonPointerEscape(key)}
+}
+
+// jsonPointerEscape escapes s for use as a single segment of a JSON
+// Pointer (RFC 6901), so that package paths containing "/" don't get
+// misread as additional pointer segments.
+func jsonPointerEscape(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	s = strings.ReplaceAll(s, "/", "~1")
+	return s
+}
+
+// isNamedStructType reports whether typ looks like a bare Go type name
+// (as opposed to one of the synthetic strings getType produces, or a
+// primitive type), which getType emits for named struct fields it
+// didn't otherwise recognize.
+func isNamedStructType(typ string) bool {
+	if typ == "" {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(typ)
+	return unicode.IsUpper(r)
+}
+
+func goTypeToJSONSchemaType(typ string) string {
+	switch typ {
+	case "bool":
+		return "boolean"
+	case "string":
+		return "string"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	default:
+		return "string"
+	}
+}
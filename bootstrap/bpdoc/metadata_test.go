@@ -0,0 +1,135 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMetadataTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		tag            string
+		wantDeprecated string
+		wantSince      string
+		wantStability  string
+	}{
+		{
+			name:           "all three",
+			tag:            `blueprint:"deprecated=use Foo instead,since=9,stability=experimental"`,
+			wantDeprecated: "use Foo instead",
+			wantSince:      "9",
+			wantStability:  "experimental",
+		},
+		{
+			name:           "deprecation message containing commas",
+			tag:            `blueprint:"deprecated=use Foo instead, see docs for more info,since=9"`,
+			wantDeprecated: "use Foo instead, see docs for more info",
+			wantSince:      "9",
+		},
+		{
+			name:           "bare tags alongside metadata are ignored",
+			tag:            `blueprint:"doc_exclude,since=9"`,
+			wantDeprecated: "",
+			wantSince:      "9",
+		},
+		{
+			name: "no blueprint tag",
+			tag:  `json:"foo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deprecated, since, stability := parseMetadataTag(reflect.StructTag(tt.tag))
+			if deprecated != tt.wantDeprecated {
+				t.Errorf("deprecated = %q, want %q", deprecated, tt.wantDeprecated)
+			}
+			if since != tt.wantSince {
+				t.Errorf("since = %q, want %q", since, tt.wantSince)
+			}
+			if stability != tt.wantStability {
+				t.Errorf("stability = %q, want %q", stability, tt.wantStability)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"10", "9", 1},
+		{"1.2", "1.2", 0},
+		{"1.10", "1.9", 1},
+		{"1.2", "1.2.1", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestExcludeDeprecated(t *testing.T) {
+	ps := &PropertyStruct{
+		Properties: []Property{
+			{Name: "old", Deprecated: "use new instead"},
+			{Name: "new"},
+		},
+	}
+	ps.ExcludeDeprecated()
+
+	if findProperty(ps.Properties, "old") != nil {
+		t.Errorf("ExcludeDeprecated did not remove deprecated property")
+	}
+	if findProperty(ps.Properties, "new") == nil {
+		t.Errorf("ExcludeDeprecated removed a non-deprecated property")
+	}
+}
+
+func TestFilterBySince(t *testing.T) {
+	ps := &PropertyStruct{
+		Properties: []Property{
+			{Name: "old", Since: "5"},
+			{Name: "new", Since: "10"},
+			{Name: "unversioned"},
+		},
+	}
+	ps.FilterBySince("9")
+
+	if findProperty(ps.Properties, "old") == nil {
+		t.Errorf("FilterBySince removed a property older than minVer")
+	}
+	if findProperty(ps.Properties, "new") != nil {
+		t.Errorf("FilterBySince did not remove a property newer than minVer")
+	}
+	if findProperty(ps.Properties, "unversioned") == nil {
+		t.Errorf("FilterBySince removed an unversioned property")
+	}
+}
+
+func findProperty(props []Property, name string) *Property {
+	for i := range props {
+		if props[i].Name == name {
+			return &props[i]
+		}
+	}
+	return nil
+}
@@ -56,6 +56,9 @@ func (p *Property) Clone() Property {
 func (p *Property) Equal(other Property) bool {
 	return p.Name == other.Name && p.Type == other.Type && p.Tag == other.Tag &&
 		p.Text == other.Text && p.Default == other.Default &&
+		p.Deprecated == other.Deprecated && p.Since == other.Since &&
+		p.Stability == other.Stability &&
+		p.RefPkg == other.RefPkg && p.RefName == other.RefName &&
 		stringArrayEqual(p.OtherNames, other.OtherNames) &&
 		htmlArrayEqual(p.OtherTexts, other.OtherTexts) &&
 		p.SameSubProperties(other)
@@ -235,12 +238,17 @@ func structProperties(structType *ast.StructType) (props []Property, err error)
 				return nil, err
 			}
 
+			deprecated, since, stability := parseMetadataTag(reflect.StructTag(tag))
+
 			props = append(props, Property{
 				Name:       name,
 				Type:       typ,
 				Tag:        reflect.StructTag(tag),
 				Text:       formatText(text),
 				Properties: innerProps,
+				Deprecated: deprecated,
+				Since:      since,
+				Stability:  stability,
 			})
 		}
 	}
@@ -268,25 +276,34 @@ func getType(expr ast.Expr) (typ string, innerProps []Property, err error) {
 	case *ast.Ident:
 		typ = a.Name
 	case *ast.StructType:
+		typ = "struct"
 		innerProps, err = structProperties(a)
 		if err != nil {
 			return "", nil, err
 		}
 	case *ast.IndexExpr:
-		// IndexExpr is used to represent generic type arguments
-		if !isConfigurableAst(a.X) {
-			var writer strings.Builder
-			if err := ast.Fprint(&writer, nil, expr, nil); err != nil {
-				return "", nil, err
-			}
-			return "", nil, fmt.Errorf("unknown type %s", writer.String())
+		// IndexExpr is used to represent a generic type with a single type argument.
+		typ, innerProps, err = getGenericType(a.X, []ast.Expr{a.Index})
+		if err != nil {
+			return "", nil, err
+		}
+	case *ast.IndexListExpr:
+		// IndexListExpr is used to represent a generic type with multiple type arguments.
+		typ, innerProps, err = getGenericType(a.X, a.Indices)
+		if err != nil {
+			return "", nil, err
+		}
+	case *ast.MapType:
+		keyType, _, err := getType(a.Key)
+		if err != nil {
+			return "", nil, err
 		}
-		var innerType string
-		innerType, innerProps, err = getType(a.Index)
+		var valType string
+		valType, innerProps, err = getType(a.Value)
 		if err != nil {
 			return "", nil, err
 		}
-		typ = "configurable " + innerType
+		typ = "map of " + keyType + " to " + valType
 	default:
 		typ = fmt.Sprintf("%T", expr)
 	}
@@ -294,18 +311,83 @@ func getType(expr ast.Expr) (typ string, innerProps []Property, err error) {
 	return typ, innerProps, nil
 }
 
-func isConfigurableAst(expr ast.Expr) bool {
+// genericWrappers holds the renderers for generic property types whose base
+// name doesn't follow the default "lowercased name" convention. Built-in
+// wrappers (Configurable, Optional, Union, ...) are handled by
+// defaultGenericFormat; RegisterGenericWrapper lets out-of-tree generic
+// types plug in their own rendering.
+var genericWrappers = map[string]func(inner ...string) string{}
+
+// RegisterGenericWrapper registers a custom renderer for a generic property
+// type named name, overriding the default rendering that getType would
+// otherwise produce for it. format is called with the rendered form of each
+// of the type's parameters, in order, and returns the string getType should
+// use as the property's type.
+func RegisterGenericWrapper(name string, format func(inner ...string) string) {
+	genericWrappers[name] = format
+}
+
+// defaultGenericFormat is used for generic types that have no registered
+// wrapper. A single type parameter is rendered as "name param" (e.g.
+// "configurable bool", "optional bool"); multiple type parameters are
+// rendered as "name<param1,param2>" (e.g. "union<string,int>").
+func defaultGenericFormat(name string) func(inner ...string) string {
+	lower := strings.ToLower(name)
+	return func(inner ...string) string {
+		if len(inner) == 1 {
+			return lower + " " + inner[0]
+		}
+		return lower + "<" + strings.Join(inner, ",") + ">"
+	}
+}
+
+// getGenericType describes a generic property type given its base type
+// (e.g. `proptools.Configurable` or `Optional`) and its type parameters. It
+// resolves the base name from the AST, recursively describes each type
+// parameter via getType, and renders the result using any wrapper
+// registered with RegisterGenericWrapper, falling back to
+// defaultGenericFormat. Inner struct properties from every type parameter
+// are concatenated, in parameter order, so nested docs still work even for
+// multi-parameter generics like Union[struct{...}, struct{...}].
+func getGenericType(base ast.Expr, params []ast.Expr) (typ string, innerProps []Property, err error) {
+	name, err := genericBaseName(base)
+	if err != nil {
+		return "", nil, err
+	}
+
+	inner := make([]string, len(params))
+	for i, param := range params {
+		paramType, paramProps, err := getType(param)
+		if err != nil {
+			return "", nil, err
+		}
+		inner[i] = paramType
+		innerProps = append(innerProps, paramProps...)
+	}
+
+	format, ok := genericWrappers[name]
+	if !ok {
+		format = defaultGenericFormat(name)
+	}
+
+	return format(inner...), innerProps, nil
+}
+
+// genericBaseName resolves the name of a generic type's base, e.g. "Configurable"
+// for both `Configurable[T]` and `proptools.Configurable[T]`.
+func genericBaseName(expr ast.Expr) (string, error) {
 	switch e := expr.(type) {
 	case *ast.Ident:
-		return e.Name == "Configurable"
+		return e.Name, nil
 	case *ast.SelectorExpr:
-		if l, ok := e.X.(*ast.Ident); ok && l.Name == "proptools" {
-			if e.Sel.Name == "Configurable" {
-				return true
-			}
+		return e.Sel.Name, nil
+	default:
+		var writer strings.Builder
+		if err := ast.Fprint(&writer, nil, expr, nil); err != nil {
+			return "", err
 		}
+		return "", fmt.Errorf("unknown generic base type %s", writer.String())
 	}
-	return false
 }
 
 func (ps *PropertyStruct) ExcludeByTag(key, value string) {
@@ -316,6 +398,111 @@ func (ps *PropertyStruct) IncludeByTag(key, value string) {
 	filterPropsByTag(&ps.Properties, key, value, false)
 }
 
+// ExcludeDeprecated removes all properties (and nested properties) that
+// carry a `blueprint:"deprecated=..."` struct tag, so that doc pipelines
+// can render a reference that only shows the current API surface.
+func (ps *PropertyStruct) ExcludeDeprecated() {
+	filterPropsByPredicate(&ps.Properties, func(p *Property) bool {
+		return p.Deprecated == ""
+	})
+}
+
+// FilterBySince removes all properties (and nested properties) whose
+// `blueprint:"since=..."` struct tag is newer than minVer, so that doc
+// pipelines can render the reference as it looked at an older version.
+// Properties with no Since tag are always kept.
+func (ps *PropertyStruct) FilterBySince(minVer string) {
+	filterPropsByPredicate(&ps.Properties, func(p *Property) bool {
+		return p.Since == "" || compareVersions(p.Since, minVer) <= 0
+	})
+}
+
+// filterPropsByPredicate keeps only the properties for which keep returns
+// true, recursing into the nested properties of the ones that are kept.
+func filterPropsByPredicate(props *[]Property, keep func(p *Property) bool) {
+	// Create a slice that shares the storage of props but has 0 length.  Appending up to
+	// len(props) times to this slice will overwrite the original slice contents
+	filtered := (*props)[:0]
+	for _, x := range *props {
+		if keep(&x) {
+			filterPropsByPredicate(&x.Properties, keep)
+			filtered = append(filtered, x)
+		}
+	}
+
+	*props = filtered
+}
+
+// parseMetadataTag extracts the deprecated, since, and stability values
+// from a `blueprint:"deprecated=<msg>,since=<ver>,stability=<level>"`
+// struct tag. Entries that don't match one of those prefixes (e.g. the
+// existing bare-word tags consumed by filterPropsByTag) are ignored when
+// they come before any metadata entry; after one, they're taken as part
+// of its value, as described below.
+//
+// The tag is comma-separated, but deprecated's <msg> is free text that
+// routinely contains commas of its own, so this can't just split on ","
+// and match each piece against a prefix: a comma only starts a new entry
+// when what follows it is itself one of the three known prefixes;
+// otherwise it's treated as part of the current entry's value.
+func parseMetadataTag(tag reflect.StructTag) (deprecated, since, stability string) {
+	raw := tag.Get("blueprint")
+	if raw == "" {
+		return "", "", ""
+	}
+
+	var current *string
+	for _, entry := range strings.Split(raw, ",") {
+		switch {
+		case strings.HasPrefix(entry, "deprecated="):
+			deprecated = strings.TrimPrefix(entry, "deprecated=")
+			current = &deprecated
+		case strings.HasPrefix(entry, "since="):
+			since = strings.TrimPrefix(entry, "since=")
+			current = &since
+		case strings.HasPrefix(entry, "stability="):
+			stability = strings.TrimPrefix(entry, "stability=")
+			current = &stability
+		case current != nil:
+			*current += "," + entry
+		}
+	}
+	return deprecated, since, stability
+}
+
+// compareVersions compares two dot-separated version strings segment by
+// segment, treating numeric segments numerically so that "9" < "10". It
+// returns -1, 0, or 1, the same convention as strings.Compare.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv string
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		an, aerr := strconv.Atoi(av)
+		bn, berr := strconv.Atoi(bv)
+		if aerr == nil && berr == nil {
+			switch {
+			case an < bn:
+				return -1
+			case an > bn:
+				return 1
+			default:
+				continue
+			}
+		}
+		if cmp := strings.Compare(av, bv); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
 func filterPropsByTag(props *[]Property, key, value string, exclude bool) {
 	// Create a slice that shares the storage of props but has 0 length.  Appending up to
 	// len(props) times to this slice will overwrite the original slice contents
@@ -0,0 +1,112 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const mapPropsSrc = `
+package test
+
+type MapProps struct {
+	// Prims is a map of primitives.
+	Prims map[string]string
+
+	// Structs is a map of structs.
+	Structs map[string]SubStruct
+
+	// Inline is a map of an anonymous struct.
+	Inline map[string]struct {
+		// Name is a field of the anonymous struct.
+		Name string
+	}
+
+	// Configs is a map of Configurable values.
+	Configs map[string]proptools.Configurable[string]
+}
+
+type SubStruct struct {
+	// Name is a field of SubStruct.
+	Name string
+}
+`
+
+// mapPropsStructType parses mapPropsSrc and returns the *ast.StructType for
+// the named top-level type declaration.
+func mapPropsStructType(t *testing.T, name string) *ast.StructType {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "mapprops.go", mapPropsSrc, 0)
+	if err != nil {
+		t.Fatalf("failed to parse test source: %s", err)
+	}
+
+	var structType *ast.StructType
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != name {
+			return true
+		}
+		structType, _ = ts.Type.(*ast.StructType)
+		return false
+	})
+	if structType == nil {
+		t.Fatalf("could not find struct type %q in test source", name)
+	}
+	return structType
+}
+
+func TestStructPropertiesMapTypes(t *testing.T) {
+	props, err := structProperties(mapPropsStructType(t, "MapProps"))
+	if err != nil {
+		t.Fatalf("structProperties returned error: %s", err)
+	}
+
+	tests := []struct {
+		name      string
+		wantType  string
+		wantInner []string
+	}{
+		{name: "prims", wantType: "map of string to string"},
+		{name: "structs", wantType: "map of string to SubStruct"},
+		{name: "inline", wantType: "map of string to struct", wantInner: []string{"name"}},
+		{name: "configs", wantType: "map of string to configurable string"},
+	}
+
+	for _, test := range tests {
+		prop := findProperty(props, test.name)
+		if prop == nil {
+			t.Errorf("missing property %q", test.name)
+			continue
+		}
+		if prop.Type != test.wantType {
+			t.Errorf("property %q: got type %q, want %q", test.name, prop.Type, test.wantType)
+		}
+		if len(prop.Properties) != len(test.wantInner) {
+			t.Errorf("property %q: got %d nested properties, want %d", test.name, len(prop.Properties), len(test.wantInner))
+			continue
+		}
+		for i, wantName := range test.wantInner {
+			if prop.Properties[i].Name != wantName {
+				t.Errorf("property %q: nested property %d = %q, want %q", test.name, i, prop.Properties[i].Name, wantName)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// Renderer converts a PropertyStruct into some textual representation,
+// for example Markdown, JSON Schema, or YAML. It allows callers that
+// don't want to scrape the HTML produced by formatText to consume
+// property documentation in whatever shape suits their tooling.
+type Renderer interface {
+	Render(ps *PropertyStruct) (string, error)
+}
+
+// RendererFunc adapts a function to the Renderer interface.
+type RendererFunc func(ps *PropertyStruct) (string, error)
+
+func (f RendererFunc) Render(ps *PropertyStruct) (string, error) {
+	return f(ps)
+}
+
+// MarkdownRenderer renders a PropertyStruct as Markdown.
+var MarkdownRenderer Renderer = RendererFunc(renderMarkdown)
+
+// JSONSchemaRenderer renders a PropertyStruct as a JSON Schema document.
+var JSONSchemaRenderer Renderer = RendererFunc(renderJSONSchema)
+
+// YAMLRenderer renders a PropertyStruct as YAML.
+var YAMLRenderer Renderer = RendererFunc(renderYAML)
+
+// RenderMarkdown renders the property struct as a Markdown document
+// describing each property, its type, and its default value.
+func (ps *PropertyStruct) RenderMarkdown() (string, error) {
+	return MarkdownRenderer.Render(ps)
+}
+
+// RenderJSONSchema renders the property struct as a JSON Schema document
+// that can be used to validate or autocomplete Android.bp properties.
+func (ps *PropertyStruct) RenderJSONSchema() (string, error) {
+	return JSONSchemaRenderer.Render(ps)
+}
+
+// RenderYAML renders the property struct as a YAML document describing
+// each property, its type, and its default value.
+func (ps *PropertyStruct) RenderYAML() (string, error) {
+	return YAMLRenderer.Render(ps)
+}
+
+func renderMarkdown(ps *PropertyStruct) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", ps.Name)
+	if ps.Text != "" {
+		fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(ps.Text))
+	}
+	renderMarkdownProperties(&sb, ps.Properties, 0)
+	return sb.String(), nil
+}
+
+func renderMarkdownProperties(sb *strings.Builder, props []Property, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, p := range props {
+		fmt.Fprintf(sb, "%s* **%s** (%s)", indent, p.Name, p.Type)
+		if p.RefName != "" {
+			fmt.Fprintf(sb, " — see [%s](#%s)", p.RefName, strings.ToLower(p.RefName))
+		}
+		if p.Default != "" {
+			fmt.Fprintf(sb, " — default: `%s`", p.Default)
+		}
+		if p.Deprecated != "" {
+			fmt.Fprintf(sb, " — **deprecated:** %s", p.Deprecated)
+		}
+		if p.Since != "" {
+			fmt.Fprintf(sb, " — since %s", p.Since)
+		}
+		if p.Stability != "" {
+			fmt.Fprintf(sb, " — %s", p.Stability)
+		}
+		sb.WriteString("\n")
+		if text := htmlToText(p.Text); text != "" {
+			fmt.Fprintf(sb, "%s  %s\n", indent, text)
+		}
+		if len(p.Properties) > 0 {
+			renderMarkdownProperties(sb, p.Properties, depth+1)
+		}
+	}
+}
+
+// htmlToText reverses the minimal HTML formatText produces — <pre> blocks
+// and HTML-escaped entities — back down to plain text suitable for
+// embedding in Markdown, JSON, or YAML output.
+func htmlToText(h template.HTML) string {
+	s := strings.ReplaceAll(string(h), "<pre>", "")
+	s = strings.ReplaceAll(s, "</pre>", "")
+	return strings.TrimSpace(html.UnescapeString(s))
+}
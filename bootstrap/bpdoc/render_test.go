@@ -0,0 +1,122 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func testPropertyStruct() *PropertyStruct {
+	return &PropertyStruct{
+		Name: "Props",
+		Text: "Props holds some properties.",
+		Properties: []Property{
+			{
+				Name: "name",
+				Type: "string",
+				Text: formatText("the name"),
+			},
+			{
+				Name: "srcs",
+				Type: "list of string",
+				Text: formatText("uses A < B & C > D"),
+			},
+			{
+				Name: "nested",
+				Type: "struct",
+				Properties: []Property{
+					{Name: "inner", Type: "bool"},
+				},
+			},
+			{
+				Name: "hidden",
+				Type: "string",
+				Tag:  `blueprint:"doc_exclude"`,
+			},
+		},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out, err := testPropertyStruct().RenderMarkdown()
+	if err != nil {
+		t.Fatalf("RenderMarkdown returned error: %s", err)
+	}
+	if !strings.Contains(out, "# Props") {
+		t.Errorf("RenderMarkdown output missing title:\n%s", out)
+	}
+	if !strings.Contains(out, "uses A < B & C > D") {
+		t.Errorf("RenderMarkdown output did not unescape HTML entities:\n%s", out)
+	}
+	if !strings.Contains(out, "**nested**") || !strings.Contains(out, "**inner**") {
+		t.Errorf("RenderMarkdown output missing nested properties:\n%s", out)
+	}
+}
+
+func TestRenderYAML(t *testing.T) {
+	out, err := testPropertyStruct().RenderYAML()
+	if err != nil {
+		t.Fatalf("RenderYAML returned error: %s", err)
+	}
+	if !strings.Contains(out, "name: \"Props\"") {
+		t.Errorf("RenderYAML output missing name:\n%s", out)
+	}
+	if !strings.Contains(out, "uses A < B & C > D") {
+		t.Errorf("RenderYAML output did not unescape HTML entities:\n%s", out)
+	}
+}
+
+func TestRenderJSONSchemaTypeDispatch(t *testing.T) {
+	out, err := testPropertyStruct().RenderJSONSchema()
+	if err != nil {
+		t.Fatalf("RenderJSONSchema returned error: %s", err)
+	}
+	for _, want := range []string{
+		`"srcs"`, `"type": "array"`,
+		`"nested"`, `"inner"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderJSONSchema output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestRenderersFilterConsistently verifies that ExcludeByTag hides a
+// property from all three renderers alike, since none of them does its
+// own tag-based filtering.
+func TestRenderersFilterConsistently(t *testing.T) {
+	ps := testPropertyStruct()
+	ps.ExcludeByTag("blueprint", "doc_exclude")
+
+	md, err := ps.RenderMarkdown()
+	if err != nil {
+		t.Fatalf("RenderMarkdown returned error: %s", err)
+	}
+	yaml, err := ps.RenderYAML()
+	if err != nil {
+		t.Fatalf("RenderYAML returned error: %s", err)
+	}
+	schema, err := ps.RenderJSONSchema()
+	if err != nil {
+		t.Fatalf("RenderJSONSchema returned error: %s", err)
+	}
+
+	for name, out := range map[string]string{"Markdown": md, "YAML": yaml, "JSONSchema": schema} {
+		if strings.Contains(out, "hidden") {
+			t.Errorf("%s output still contains excluded property:\n%s", name, out)
+		}
+	}
+}
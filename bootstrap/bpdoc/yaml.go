@@ -0,0 +1,81 @@
+// Copyright 2024 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpdoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderYAML emits a PropertyStruct as a small, self-contained YAML
+// document. Blueprint avoids taking on an external YAML dependency just
+// for documentation output, so this writes the handful of constructs
+// (mappings and scalars) that property docs actually need.
+func renderYAML(ps *PropertyStruct) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "name: %s\n", yamlScalar(ps.Name))
+	if ps.Text != "" {
+		fmt.Fprintf(&sb, "description: %s\n", yamlScalar(strings.TrimSpace(ps.Text)))
+	}
+	sb.WriteString("properties:\n")
+	writeYAMLProperties(&sb, ps.Properties, 1)
+	return sb.String(), nil
+}
+
+func writeYAMLProperties(sb *strings.Builder, props []Property, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, p := range props {
+		fmt.Fprintf(sb, "%s%s:\n", indent, yamlKey(p.Name))
+		fmt.Fprintf(sb, "%s  type: %s\n", indent, yamlScalar(p.Type))
+		if p.RefName != "" {
+			fmt.Fprintf(sb, "%s  $ref: %s\n", indent, yamlScalar(refKey(p.RefPkg, p.RefName)))
+		}
+		if text := htmlToText(p.Text); text != "" {
+			fmt.Fprintf(sb, "%s  description: %s\n", indent, yamlScalar(text))
+		}
+		if p.Default != "" {
+			fmt.Fprintf(sb, "%s  default: %s\n", indent, yamlScalar(p.Default))
+		}
+		if p.Deprecated != "" {
+			fmt.Fprintf(sb, "%s  deprecated: %s\n", indent, yamlScalar(p.Deprecated))
+		}
+		if p.Since != "" {
+			fmt.Fprintf(sb, "%s  since: %s\n", indent, yamlScalar(p.Since))
+		}
+		if p.Stability != "" {
+			fmt.Fprintf(sb, "%s  stability: %s\n", indent, yamlScalar(p.Stability))
+		}
+		if len(p.Properties) > 0 {
+			fmt.Fprintf(sb, "%s  properties:\n", indent)
+			writeYAMLProperties(sb, p.Properties, depth+2)
+		}
+	}
+}
+
+// yamlKey quotes a mapping key if it contains characters that would
+// otherwise change its meaning in YAML.
+func yamlKey(s string) string {
+	if s == "" || strings.ContainsAny(s, ": \t\n") {
+		return yamlScalar(s)
+	}
+	return s
+}
+
+// yamlScalar renders s as a double-quoted YAML scalar, which is always
+// valid regardless of its contents.
+func yamlScalar(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}